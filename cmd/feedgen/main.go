@@ -0,0 +1,153 @@
+// Command feedgen reads the transcript JSON files produced by the
+// pilkipedia-scraper and renders them as a paginated Atom 1.0 feed, with
+// an optional OPML document for subscribing to one feed per publication.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/warmans/pilkipedia-scraper/pkg/feed"
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+)
+
+func main() {
+	rawDir := flag.String("raw-dir", "./raw", "directory containing transcript-*.json files")
+	outDir := flag.String("out-dir", "./feed", "directory to write feed-*.xml (and opml.xml) to")
+	baseURL := flag.String("base-url", "", "base URL the feed is published under, used for self/next/prev links")
+	pageSize := flag.Int("page-size", 20, "number of entries per feed page")
+	title := flag.String("title", "Pilkipedia Transcripts", "title used for the combined feed")
+	author := flag.String("author", "Pilkipedia Scraper", "feed author name, required by the Atom spec")
+	opml := flag.Bool("opml", false, "also write opml.xml with one feed per publication, under out-dir/<publication>/")
+	flag.Parse()
+
+	episodes, err := loadEpisodes(*rawDir)
+	if err != nil {
+		log.Fatalf("failed to load episodes: %s", err)
+	}
+
+	entries, skipped := buildEntries(episodes)
+	if skipped > 0 {
+		fmt.Printf("skipped %d episode(s) with missing or unparseable dates\n", skipped)
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("failed to create out-dir %q: %s", *outDir, err)
+	}
+
+	if err := writeFeedPages(entries, *title, *author, *baseURL+"/", "feed-%d.xml", *outDir, *pageSize); err != nil {
+		log.Fatalf("failed to write feed: %s", err)
+	}
+
+	if *opml {
+		if err := writePerPublicationFeeds(episodes, *author, *baseURL, *outDir, *pageSize); err != nil {
+			log.Fatalf("failed to write per-publication feeds: %s", err)
+		}
+	}
+}
+
+var slugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a publication name (e.g. "6 Music", "na") into a string
+// that's safe to use as both a directory name and a URL path segment.
+func slugify(s string) string {
+	s = slugInvalid.ReplaceAllString(strings.ToLower(strings.TrimSpace(s)), "-")
+	s = strings.Trim(s, "-")
+	if s == "" {
+		s = "unknown"
+	}
+	return s
+}
+
+func loadEpisodes(rawDir string) ([]models.Episode, error) {
+	paths, err := filepath.Glob(filepath.Join(rawDir, "transcript-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	episodes := make([]models.Episode, 0, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", path, err)
+		}
+		var ep models.Episode
+		if err := json.Unmarshal(raw, &ep); err != nil {
+			return nil, fmt.Errorf("unmarshalling %q: %w", path, err)
+		}
+		episodes = append(episodes, ep)
+	}
+	return episodes, nil
+}
+
+// buildEntries converts episodes to Atom entries, skipping (and counting)
+// any that can't be dated.
+func buildEntries(episodes []models.Episode) ([]feed.AtomEntry, int) {
+	entries := make([]feed.AtomEntry, 0, len(episodes))
+	skipped := 0
+	for _, ep := range episodes {
+		entry, err := feed.BuildEntry(ep)
+		if err != nil {
+			skipped++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped
+}
+
+func writeFeedPages(entries []feed.AtomEntry, title, author, baseURL, pageURLFormat, outDir string, pageSize int) error {
+	pages := feed.Paginate(title, baseURL, author, baseURL, pageURLFormat, entries, pageSize)
+	for i, page := range pages {
+		out, err := feed.Marshal(page)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(outDir, fmt.Sprintf(pageURLFormat, i+1))
+		if err := os.WriteFile(path, out, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePerPublicationFeeds(episodes []models.Episode, author, baseURL, outDir string, pageSize int) error {
+	byPublication := map[string][]models.Episode{}
+	for _, ep := range episodes {
+		publication := ep.MetaValue(models.MetadataTypePublication)
+		byPublication[publication] = append(byPublication[publication], ep)
+	}
+
+	feedURLs := map[string]string{}
+	for publication, eps := range byPublication {
+		entries, _ := buildEntries(eps)
+
+		slug := slugify(publication)
+
+		pubDir := filepath.Join(outDir, slug)
+		if err := os.MkdirAll(pubDir, 0755); err != nil {
+			return err
+		}
+
+		pubBaseURL := fmt.Sprintf("%s/%s/", baseURL, slug)
+		if err := writeFeedPages(entries, publication, author, pubBaseURL, "feed-%d.xml", pubDir, pageSize); err != nil {
+			return err
+		}
+		feedURLs[publication] = pubBaseURL + "feed-1.xml"
+	}
+
+	doc := feed.BuildOPML("Pilkipedia Transcripts", feedURLs)
+	out, err := doc.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outDir, "opml.xml"), out, 0644)
+}
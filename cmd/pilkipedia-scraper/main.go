@@ -0,0 +1,202 @@
+// Command pilkipedia-scraper crawls a transcript wiki (selected via
+// --adapter) and writes one JSON file per episode under ./raw.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/warmans/pilkipedia-scraper/pkg/dateparse"
+	"github.com/warmans/pilkipedia-scraper/pkg/manifest"
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+	"github.com/warmans/pilkipedia-scraper/pkg/scrape"
+	"github.com/warmans/pilkipedia-scraper/pkg/scrape/pilkipedia"
+)
+
+const manifestPath = "./raw/manifest.json"
+
+func main() {
+	adapterFlag := flag.String("adapter", "pilkipedia", "site adapter to scrape with (pilkipedia)")
+	dialectFlag := flag.String("dialect", "uk", "date dialect hint (uk|us) used to disambiguate ambiguous numeric dates")
+	force := flag.Bool("force", false, "re-fetch every page without the conditional headers recorded in raw/manifest.json")
+	probeMedia := flag.Bool("probe-media", false, "issue a HEAD request for each media enclosure to fill in its size and MIME type")
+	flag.Parse()
+
+	dialect := dateparse.DialectUK
+	if *dialectFlag == "us" {
+		dialect = dateparse.DialectUS
+	}
+
+	adapter, err := newAdapter(*adapterFlag, dialect)
+	if err != nil {
+		log.Fatalf("failed to build adapter: %s", err)
+	}
+
+	man, err := manifest.Load(manifestPath)
+	if err != nil {
+		log.Fatalf("failed to load manifest: %s", err)
+	}
+
+	summary := manifest.Summary{}
+
+	indexer := colly.NewCollector(
+		colly.AllowedDomains(adapter.AllowedDomains()...),
+
+		// Cache responses to prevent multiple download of pages
+		// even if the collector is restarted
+		colly.CacheDir("./archive_org_cache"),
+	)
+
+	episodeDetailsCollector := indexer.Clone()
+
+	// The on-disk cache short-circuits the HTTP request entirely for any
+	// URL it already has a file for, so If-None-Match/If-Modified-Since
+	// below would never reach the network. Conditional re-scraping is
+	// handled by the manifest instead, so episode pages must not be cached.
+	episodeDetailsCollector.CacheDir = ""
+
+	// colly treats a non-2xx response (including our expected 304s) as an
+	// error and routes it to OnError instead of OnResponse unless told
+	// otherwise.
+	episodeDetailsCollector.ParseHTTPErrorResponse = true
+
+	indexer.OnHTML(adapter.IndexSelector(), func(e *colly.HTMLElement) {
+		if adapter.IsEpisodeLink(e) {
+			episodeDetailsCollector.Visit(e.Request.AbsoluteURL(e.Attr("href")))
+		}
+	})
+
+	episodeDetailsCollector.OnRequest(func(r *colly.Request) {
+		if *force {
+			return
+		}
+		if entry, ok := man.Get(r.URL.String()); ok {
+			if entry.ETag != "" {
+				r.Headers.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				r.Headers.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	})
+
+	episodeDetailsCollector.OnResponse(func(r *colly.Response) {
+		if r.StatusCode == http.StatusNotModified {
+			fmt.Println("Unchanged: ", r.Request.URL)
+			summary.Unchanged++
+		}
+	})
+
+	episodeDetailsCollector.OnError(func(r *colly.Response, err error) {
+		handleEpisodeError(&summary, r, err)
+	})
+
+	// per page scraper
+	episodeDetailsCollector.OnHTML(adapter.ContentSelector(), func(e *colly.HTMLElement) {
+
+		episode := models.Episode{
+			Transcript: []models.Dialog{},
+			Meta:       []models.Metadata{},
+		}
+
+		fmt.Println("Loaded page ", e.Request.URL)
+		episode.Source = e.Request.URL.String()
+
+		fmt.Println("Parsing meta...")
+		meta, err := adapter.ParseMeta(e)
+		if err != nil {
+			fmt.Printf("Failed to parse meta: %s", err.Error())
+			summary.Failed++
+			return
+		}
+		episode.Meta = meta
+
+		transcript, err := adapter.ParseTranscript(e)
+		if err != nil {
+			fmt.Printf("Failed to parse transcript: %s", err.Error())
+			summary.Failed++
+			return
+		}
+		episode.Transcript = transcript
+
+		episode.Media = scrape.ParseMedia(e)
+		if *probeMedia {
+			scrape.ProbeMedia(episode.Media)
+		}
+
+		prevEntry, seenBefore := man.Get(episode.Source)
+
+		canonicalName := episode.CanonicalName()
+		if seenBefore && prevEntry.CanonicalName != "" {
+			// reuse the prior canonical name so a metadata-parsing change
+			// doesn't silently orphan the old output file
+			canonicalName = prevEntry.CanonicalName
+		}
+
+		out, err := json.MarshalIndent(episode, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode JSON: %s\n", err)
+		}
+		sha := fmt.Sprintf("%x", sha256.Sum256(out))
+
+		fName := fmt.Sprintf("./raw/transcript-%s.json", canonicalName)
+		if err := os.WriteFile(fName, out, 0644); err != nil {
+			log.Fatalf("Cannot write file %q: %s\n", fName, err)
+		}
+
+		switch {
+		case !seenBefore:
+			summary.New++
+		case prevEntry.SHA256 != sha:
+			summary.Updated++
+		default:
+			summary.Unchanged++
+		}
+
+		man.Set(episode.Source, manifest.Entry{
+			ETag:          e.Response.Headers.Get("ETag"),
+			LastModified:  e.Response.Headers.Get("Last-Modified"),
+			SHA256:        sha,
+			CanonicalName: canonicalName,
+		})
+	})
+
+	if err := indexer.Visit(adapter.StartURL()); err != nil {
+		log.Fatalf("failed visit top level URL: %s", err)
+	}
+
+	if err := man.Save(manifestPath); err != nil {
+		log.Fatalf("failed to save manifest: %s", err)
+	}
+
+	fmt.Println(summary.String())
+}
+
+// handleEpisodeError records an episodeDetailsCollector.OnError callback.
+// A 304 is not a failure (with ParseHTTPErrorResponse set, some colly
+// versions still route it here rather than to OnResponse), so it's
+// counted as Unchanged rather than Failed.
+func handleEpisodeError(summary *manifest.Summary, r *colly.Response, err error) {
+	if r != nil && r.StatusCode == http.StatusNotModified {
+		fmt.Println("Unchanged: ", r.Request.URL)
+		summary.Unchanged++
+		return
+	}
+	fmt.Printf("Failed to fetch %s: %s\n", r.Request.URL, err.Error())
+	summary.Failed++
+}
+
+func newAdapter(name string, dialect dateparse.Dialect) (scrape.SiteAdapter, error) {
+	switch name {
+	case "pilkipedia":
+		return pilkipedia.New(dialect), nil
+	default:
+		return nil, fmt.Errorf("unknown adapter %q", name)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/warmans/pilkipedia-scraper/pkg/manifest"
+)
+
+func TestHandleEpisodeError_NotModifiedIsUnchanged(t *testing.T) {
+	u, _ := url.Parse("https://web.archive.org/episode-1")
+	r := &colly.Response{
+		StatusCode: http.StatusNotModified,
+		Request:    &colly.Request{URL: u},
+	}
+
+	summary := &manifest.Summary{}
+	handleEpisodeError(summary, r, fmt.Errorf("Status code: 304"))
+
+	if summary.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", summary.Unchanged)
+	}
+	if summary.Failed != 0 {
+		t.Errorf("Failed = %d, want 0", summary.Failed)
+	}
+}
+
+func TestHandleEpisodeError_OtherStatusIsFailed(t *testing.T) {
+	u, _ := url.Parse("https://web.archive.org/episode-2")
+	r := &colly.Response{
+		StatusCode: http.StatusNotFound,
+		Request:    &colly.Request{URL: u},
+	}
+
+	summary := &manifest.Summary{}
+	handleEpisodeError(summary, r, fmt.Errorf("Status code: 404"))
+
+	if summary.Failed != 1 {
+		t.Errorf("Failed = %d, want 1", summary.Failed)
+	}
+	if summary.Unchanged != 0 {
+		t.Errorf("Unchanged = %d, want 0", summary.Unchanged)
+	}
+}
@@ -0,0 +1,99 @@
+// Package dateparse parses the freeform episode dates found in the first
+// paragraph of a Pilkipedia transcript page (e.g. "15 November 2003",
+// "15th November 2003", "Nov 15, 2003", "2003-11-15" or a range like
+// "15-16 Nov 2003") into a time.Time.
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Dialect disambiguates purely numeric dates (e.g. 03/04/2006) where the
+// day/month order can't be inferred from the text alone.
+type Dialect string
+
+const (
+	DialectUK = Dialect("uk")
+	DialectUS = Dialect("us")
+)
+
+var ordinalSuffix = regexp.MustCompile(`(?i)(\d+)(st|nd|rd|th)\b`)
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// dateRange matches "15-16 Nov 2003" / "15–16 November 2003" style ranges
+// so only the first day is kept.
+var dateRange = regexp.MustCompile(`^(\d{1,2})\s*[-\x{2013}\x{2014}]\s*\d{1,2}(\s+\S.*)$`)
+
+// ukLayouts is tried for DialectUK: day-before-month layouts come first,
+// and all-numeric dates are read as day/month/year.
+var ukLayouts = []string{
+	time.RFC3339,
+	"2 January 2006",
+	"02 January 2006",
+	"January 2 2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 Jan 2006",
+	"02 Jan 2006",
+	"2006-01-02",
+	"02/01/2006",
+	"2/1/2006",
+}
+
+// usLayouts is tried for DialectUS: month-before-day layouts come first,
+// and all-numeric dates are read as month/day/year.
+var usLayouts = []string{
+	time.RFC3339,
+	"January 2 2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"02 January 2006",
+	"2 Jan 2006",
+	"02 Jan 2006",
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+// ParseEpisodeDate parses a freeform date string scraped from a transcript
+// page. dialect only matters for ambiguous all-numeric dates (e.g.
+// 03/04/2006); pass "" to fall back to DialectUK.
+func ParseEpisodeDate(raw string, dialect Dialect) (time.Time, error) {
+	clean := normalize(raw)
+	if clean == "" {
+		return time.Time{}, fmt.Errorf("dateparse: empty date string")
+	}
+
+	layouts := ukLayouts
+	if dialect == DialectUS {
+		layouts = usLayouts
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, clean); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("dateparse: unrecognised date format: %q", raw)
+}
+
+// normalize strips ordinal suffixes, collapses a date range down to its
+// first date and tidies up whitespace so the result lines up with one of
+// the known layouts.
+func normalize(raw string) string {
+	s := strings.TrimSpace(raw)
+	if m := dateRange.FindStringSubmatch(s); m != nil {
+		s = m[1] + m[2]
+	}
+	s = ordinalSuffix.ReplaceAllString(s, "$1")
+	s = whitespace.ReplaceAllString(s, " ")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,74 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEpisodeDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		dialect Dialect
+		want    time.Time
+	}{
+		{
+			name: "ordinal suffix",
+			raw:  "15th November 2003",
+			want: time.Date(2003, time.November, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "abbreviated month with comma",
+			raw:  "Nov 15, 2003",
+			want: time.Date(2003, time.November, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "ISO date",
+			raw:  "2003-11-15",
+			want: time.Date(2003, time.November, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "date range keeps the first day",
+			raw:  "15-16 Nov 2003",
+			want: time.Date(2003, time.November, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "en-dash date range keeps the first day",
+			raw:  "15–16 November 2003",
+			want: time.Date(2003, time.November, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "ambiguous numeric date, UK dialect is day/month/year",
+			raw:     "03/04/2006",
+			dialect: DialectUK,
+			want:    time.Date(2006, time.April, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "ambiguous numeric date, US dialect is month/day/year",
+			raw:     "03/04/2006",
+			dialect: DialectUS,
+			want:    time.Date(2006, time.March, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEpisodeDate(tt.raw, tt.dialect)
+			if err != nil {
+				t.Fatalf("ParseEpisodeDate(%q, %q) returned error: %s", tt.raw, tt.dialect, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseEpisodeDate(%q, %q) = %s, want %s", tt.raw, tt.dialect, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEpisodeDateError(t *testing.T) {
+	if _, err := ParseEpisodeDate("", DialectUK); err == nil {
+		t.Error("expected an error for an empty date string")
+	}
+	if _, err := ParseEpisodeDate("not a date", DialectUK); err == nil {
+		t.Error("expected an error for an unrecognised date format")
+	}
+}
@@ -0,0 +1,199 @@
+// Package feed renders scraped transcripts (see pkg/models) as an Atom 1.0
+// feed, paginated across multiple files, plus an OPML document for
+// subscribing to one feed per publication.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+)
+
+const xmlns = "http://www.w3.org/2005/Atom"
+
+// AtomFeed is a single page of entries.
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  AtomAuthor  `xml:"author"`
+	Links   []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+// AtomAuthor satisfies RFC 4287's requirement that a feed (or every one
+// of its entries) carry an author.
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Links     []AtomLink  `xml:"link,omitempty"`
+	Content   AtomContent `xml:"content"`
+}
+
+type AtomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",cdata"`
+}
+
+// BuildEntry converts a scraped episode into an Atom entry. The episode's
+// source URL is used as the entry ID, and the transcript is rendered as
+// HTML, one <p> per line of dialog.
+func BuildEntry(ep models.Episode) (AtomEntry, error) {
+	title := strings.TrimSpace(fmt.Sprintf("%s %s", ep.MetaValue(models.MetadataTypePublication), ep.MetaValue(models.MetadataTypeSeries)))
+
+	published := ep.MetaValue(models.MetadataTypeDate)
+	if published == "" {
+		return AtomEntry{}, fmt.Errorf("feed: episode %s has no date metadata", ep.Source)
+	}
+	if _, err := time.Parse(time.RFC3339, published); err != nil {
+		return AtomEntry{}, fmt.Errorf("feed: episode %s has unparseable date %q: %w", ep.Source, published, err)
+	}
+
+	return AtomEntry{
+		ID:        ep.Source,
+		Title:     title,
+		Published: published,
+		Updated:   published,
+		Links:     enclosureLinks(ep.Media),
+		Content: AtomContent{
+			Type: "html",
+			Body: renderTranscript(ep.Transcript),
+		},
+	}, nil
+}
+
+// enclosureLinks converts an episode's media enclosures into
+// rel="enclosure" links, the form podcast clients expect.
+func enclosureLinks(media []models.MediaEnclosure) []AtomLink {
+	links := make([]AtomLink, 0, len(media))
+	for _, m := range media {
+		links = append(links, AtomLink{
+			Rel:    "enclosure",
+			Href:   m.URL,
+			Type:   m.MIMEType,
+			Length: m.SizeBytes,
+		})
+	}
+	return links
+}
+
+// renderTranscript renders one <p> per line of dialog, wrapping song
+// lyrics in <i> and the speaking actor's name in <b>.
+func renderTranscript(transcript []models.Dialog) string {
+	var b strings.Builder
+	for _, line := range transcript {
+		b.WriteString("<p>")
+		if line.Actor != "" {
+			b.WriteString("<b>")
+			b.WriteString(html.EscapeString(line.Actor))
+			b.WriteString(":</b> ")
+		}
+		content := html.EscapeString(line.Content)
+		if line.Type == models.DialogTypeSong {
+			b.WriteString("<i>")
+			b.WriteString(content)
+			b.WriteString("</i>")
+		} else {
+			b.WriteString(content)
+		}
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
+
+// Paginate splits entries into pages of pageSize, producing one AtomFeed
+// per page with rel="self"/"next"/"prev" links built from baseURL and
+// pageURLFormat (e.g. "feed-%d.xml"). author is required by RFC 4287 and is
+// set on every page.
+func Paginate(title, id, author, baseURL, pageURLFormat string, entries []AtomEntry, pageSize int) []AtomFeed {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	updated := latestPublished(entries)
+
+	var pages []AtomFeed
+	for start := 0; start < len(entries) || start == 0; start += pageSize {
+		end := start + pageSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		pageNum := start/pageSize + 1
+		page := AtomFeed{
+			Xmlns:   xmlns,
+			ID:      id,
+			Title:   title,
+			Updated: updated,
+			Author:  AtomAuthor{Name: author},
+			Entries: entries[start:end],
+			Links: []AtomLink{
+				{Rel: "self", Href: baseURL + fmt.Sprintf(pageURLFormat, pageNum)},
+			},
+		}
+		if pageNum > 1 {
+			page.Links = append(page.Links, AtomLink{Rel: "prev", Href: baseURL + fmt.Sprintf(pageURLFormat, pageNum-1)})
+		}
+		if end < len(entries) {
+			page.Links = append(page.Links, AtomLink{Rel: "next", Href: baseURL + fmt.Sprintf(pageURLFormat, pageNum+1)})
+		}
+
+		pages = append(pages, page)
+
+		if end == len(entries) {
+			break
+		}
+	}
+
+	return pages
+}
+
+// latestPublished returns the most recent Published timestamp across
+// entries (entries aren't necessarily date-ordered), falling back to now
+// so an empty feed still gets a non-empty, valid <updated>.
+func latestPublished(entries []AtomEntry) string {
+	var latest time.Time
+	var latestRaw string
+	for _, e := range entries {
+		t, err := time.Parse(time.RFC3339, e.Published)
+		if err != nil {
+			continue
+		}
+		if latestRaw == "" || t.After(latest) {
+			latest = t
+			latestRaw = e.Published
+		}
+	}
+	if latestRaw == "" {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+	return latestRaw
+}
+
+// Marshal renders a feed page as indented XML with the standard header.
+func Marshal(f AtomFeed) ([]byte, error) {
+	out, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,54 @@
+package feed
+
+import "encoding/xml"
+
+// OPML is a minimal OPML 2.0 document listing one subscribable feed per
+// publication (Xfm, 6 Music, Podcast, ...).
+type OPML struct {
+	XMLName xml.Name     `xml:"opml"`
+	Version string       `xml:"version,attr"`
+	Head    OPMLHead     `xml:"head"`
+	Body    OPMLBodyList `xml:"body"`
+}
+
+type OPMLHead struct {
+	Title string `xml:"title"`
+}
+
+type OPMLBodyList struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+type OPMLOutline struct {
+	Text   string `xml:"text,attr"`
+	Title  string `xml:"title,attr"`
+	Type   string `xml:"type,attr"`
+	XMLURL string `xml:"xmlUrl,attr"`
+}
+
+// BuildOPML builds an OPML document with one outline per publication,
+// pointing at that publication's first feed page.
+func BuildOPML(docTitle string, publicationFeedURLs map[string]string) OPML {
+	opml := OPML{
+		Version: "2.0",
+		Head:    OPMLHead{Title: docTitle},
+	}
+	for publication, feedURL := range publicationFeedURLs {
+		opml.Body.Outlines = append(opml.Body.Outlines, OPMLOutline{
+			Text:   publication,
+			Title:  publication,
+			Type:   "atom",
+			XMLURL: feedURL,
+		})
+	}
+	return opml
+}
+
+// Marshal renders the OPML document as indented XML with the standard header.
+func (o OPML) Marshal() ([]byte, error) {
+	out, err := xml.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
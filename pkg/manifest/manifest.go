@@ -0,0 +1,83 @@
+// Package manifest records, per scraped episode URL, enough state (HTTP
+// cache validators, output hash, canonical filename) for the scraper to
+// skip unchanged pages on a subsequent run and avoid orphaning renamed
+// output files.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Entry is the recorded state for a single previously-scraped episode.
+type Entry struct {
+	ETag          string `json:"etag,omitempty"`
+	LastModified  string `json:"last_modified,omitempty"`
+	SHA256        string `json:"sha256_of_output"`
+	CanonicalName string `json:"canonical_name"`
+}
+
+// Manifest maps an episode's source URL to its last-known state.
+type Manifest struct {
+	Entries map[string]Entry `json:"entries"`
+}
+
+// Load reads a manifest from path. A missing file is not an error; it
+// yields an empty manifest so a first run behaves like there's nothing
+// to compare against.
+func Load(path string) (*Manifest, error) {
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Manifest{Entries: map[string]Entry{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(raw, m); err != nil {
+		return nil, err
+	}
+	if m.Entries == nil {
+		m.Entries = map[string]Entry{}
+	}
+	return m, nil
+}
+
+// Save writes the manifest to path as indented JSON.
+func (m *Manifest) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// Get returns the recorded entry for a source URL, if any.
+func (m *Manifest) Get(source string) (Entry, bool) {
+	e, ok := m.Entries[source]
+	return e, ok
+}
+
+// Set records (or replaces) the entry for a source URL.
+func (m *Manifest) Set(source string, e Entry) {
+	m.Entries[source] = e
+}
+
+// Summary counts how a scrape run treated each episode, for a one-line
+// end-of-run report.
+type Summary struct {
+	New       int
+	Updated   int
+	Unchanged int
+	Failed    int
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d new, %d updated, %d unchanged, %d failed", s.New, s.Updated, s.Unchanged, s.Failed)
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"time"
+
+	"github.com/warmans/pilkipedia-scraper/pkg/dateparse"
 )
 
 type DialogType string
@@ -33,10 +35,29 @@ type Metadata struct {
 	Value string       `json:"value"`
 }
 
+type MediaRole string
+
+const (
+	MediaRoleFullEpisode = MediaRole("full-episode")
+	MediaRoleClip        = MediaRole("clip")
+	MediaRoleSong        = MediaRole("song")
+)
+
+// MediaEnclosure is an audio/video clip found on an episode page, e.g. an
+// embedded player, a link to an MP3, or a YouTube/SoundCloud iframe.
+type MediaEnclosure struct {
+	URL       string        `json:"url"`
+	MIMEType  string        `json:"mime_type,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty"`
+	SizeBytes int64         `json:"size_bytes,omitempty"`
+	Role      MediaRole     `json:"role"`
+}
+
 type Episode struct {
-	Source     string     `json:"source"`
-	Meta       []Metadata `json:"metadata"`
-	Transcript []Dialog   `json:"transcript"`
+	Source     string           `json:"source"`
+	Meta       []Metadata       `json:"metadata"`
+	Transcript []Dialog         `json:"transcript"`
+	Media      []MediaEnclosure `json:"media,omitempty"`
 }
 
 func (e Episode) MetaValue(t MetadataType) string {
@@ -52,6 +73,9 @@ func (e Episode) CanonicalName() string {
 	date := "na"
 	if rawDate := e.MetaValue(MetadataTypeDate); rawDate != "" {
 		t, err := time.Parse(time.RFC3339, rawDate)
+		if err != nil {
+			t, err = dateparse.ParseEpisodeDate(rawDate, dateparse.DialectUK)
+		}
 		if err == nil {
 			date = t.Format("Jan-01-2006")
 		}
@@ -0,0 +1,41 @@
+// Package scrape defines the site-independent parts of scraping a
+// Pilkipedia-style transcript wiki: a pluggable SiteAdapter carries all
+// the DOM knowledge, leaving the caller to wire up colly.
+package scrape
+
+import (
+	"github.com/gocolly/colly/v2"
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+)
+
+// SiteAdapter encapsulates the DOM structure of one transcript site, so
+// that the collector wiring in cmd/pilkipedia-scraper can be shared
+// across sites that lay their pages out differently (the archived
+// Pilkipedia wiki, the live pilkipedia.com wiki, fan-maintained mirrors,
+// ...).
+type SiteAdapter interface {
+	// AllowedDomains restricts the colly collectors to this site.
+	AllowedDomains() []string
+
+	// StartURL is the index page to begin crawling from.
+	StartURL() string
+
+	// IndexSelector is the CSS selector, within the index page, that
+	// matches candidate links to episode pages.
+	IndexSelector() string
+
+	// IsEpisodeLink reports whether a link found via IndexSelector is
+	// actually a transcript page worth visiting.
+	IsEpisodeLink(e *colly.HTMLElement) bool
+
+	// ContentSelector is the CSS selector for the element containing an
+	// episode page's title, description and transcript.
+	ContentSelector() string
+
+	// ParseMeta extracts episode metadata (date, publication, series)
+	// from an episode page.
+	ParseMeta(doc *colly.HTMLElement) ([]models.Metadata, error)
+
+	// ParseTranscript extracts the dialog lines from an episode page.
+	ParseTranscript(doc *colly.HTMLElement) ([]models.Dialog, error)
+}
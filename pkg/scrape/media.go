@@ -0,0 +1,143 @@
+package scrape
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+)
+
+var audioExtensions = []string{".mp3", ".ogg", ".wav", ".m4a"}
+
+// probeClient bounds how long ProbeMedia will wait on any single
+// enclosure, so one unresponsive media host can't hang the whole scrape.
+var probeClient = &http.Client{Timeout: 10 * time.Second}
+
+// ParseMedia scans an episode page for audio/video enclosures: <audio>
+// and <video> elements (and their <source> children), anchor tags
+// linking directly to a known audio file extension, and embedded
+// YouTube/SoundCloud iframes.
+func ParseMedia(doc *colly.HTMLElement) []models.MediaEnclosure {
+	var media []models.MediaEnclosure
+
+	doc.ForEach("audio, video", func(_ int, el *colly.HTMLElement) {
+		// a <source> child, handled below, carries the same clip plus its
+		// MIME type, so don't also emit the element's own src attribute.
+		if len(el.ChildAttrs("source", "src")) > 0 {
+			return
+		}
+		if src := el.Attr("src"); src != "" {
+			media = append(media, models.MediaEnclosure{
+				URL:  el.Request.AbsoluteURL(src),
+				Role: roleFor(src),
+			})
+		}
+	})
+
+	doc.ForEach("audio source, video source", func(_ int, el *colly.HTMLElement) {
+		src := el.Attr("src")
+		if src == "" {
+			return
+		}
+		media = append(media, models.MediaEnclosure{
+			URL:      el.Request.AbsoluteURL(src),
+			MIMEType: el.Attr("type"),
+			Role:     roleFor(src),
+		})
+	})
+
+	doc.ForEach("a", func(_ int, el *colly.HTMLElement) {
+		href := el.Attr("href")
+		if href == "" || !hasAudioExtension(href) {
+			return
+		}
+		media = append(media, models.MediaEnclosure{
+			URL:  el.Request.AbsoluteURL(href),
+			Role: roleFor(href),
+		})
+	})
+
+	doc.ForEach("iframe", func(_ int, el *colly.HTMLElement) {
+		src := el.Attr("src")
+		if strings.Contains(src, "youtube.com") || strings.Contains(src, "youtu.be") || strings.Contains(src, "soundcloud.com") {
+			media = append(media, models.MediaEnclosure{
+				URL:  el.Request.AbsoluteURL(src),
+				Role: roleFor(src),
+			})
+		}
+	})
+
+	return dedupeByURL(media)
+}
+
+// dedupeByURL drops later enclosures that share a URL with one already
+// kept, e.g. an anchor linking directly to a clip that's also embedded
+// as an <audio>/<video> element.
+func dedupeByURL(media []models.MediaEnclosure) []models.MediaEnclosure {
+	seen := make(map[string]bool, len(media))
+	deduped := make([]models.MediaEnclosure, 0, len(media))
+	for _, m := range media {
+		if seen[m.URL] {
+			continue
+		}
+		seen[m.URL] = true
+		deduped = append(deduped, m)
+	}
+	return deduped
+}
+
+func hasAudioExtension(href string) bool {
+	ext := strings.ToLower(path.Ext(href))
+	for _, known := range audioExtensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// roleFor guesses a MediaRole from the URL/filename, defaulting to
+// full-episode since that's the common case on transcript pages.
+func roleFor(src string) models.MediaRole {
+	lower := strings.ToLower(src)
+	switch {
+	case strings.Contains(lower, "song"):
+		return models.MediaRoleSong
+	case strings.Contains(lower, "clip"):
+		return models.MediaRoleClip
+	default:
+		return models.MediaRoleFullEpisode
+	}
+}
+
+// ProbeMedia issues a HEAD request for each enclosure, via a client with
+// a bounded timeout, to fill in SizeBytes and MIMEType from the response
+// headers. A failed probe is logged and otherwise ignored; the enclosure
+// is kept as-is. Duration isn't set here: it isn't exposed by a HEAD
+// response and is left zero until something can read it from the media
+// itself (e.g. an ID3/container tag parse).
+func ProbeMedia(media []models.MediaEnclosure) {
+	for i := range media {
+		resp, err := probeClient.Head(media[i].URL)
+		if err != nil {
+			fmt.Printf("failed to probe media %s: %s\n", media[i].URL, err.Error())
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			fmt.Printf("failed to probe media %s: %s\n", media[i].URL, resp.Status)
+			continue
+		}
+
+		if ct := resp.Header.Get("Content-Type"); ct != "" {
+			media[i].MIMEType = ct
+		}
+		if resp.ContentLength > 0 {
+			media[i].SizeBytes = resp.ContentLength
+		}
+	}
+}
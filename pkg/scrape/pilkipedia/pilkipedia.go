@@ -0,0 +1,178 @@
+// Package pilkipedia implements scrape.SiteAdapter for the archived
+// Pilkipedia wiki (web.archive.org snapshots of pilkipedia.co.uk).
+package pilkipedia
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/warmans/pilkipedia-scraper/pkg/dateparse"
+	"github.com/warmans/pilkipedia-scraper/pkg/models"
+)
+
+// Adapter implements scrape.SiteAdapter for the archived Pilkipedia wiki.
+type Adapter struct {
+	dialect dateparse.Dialect
+}
+
+// New builds a pilkipedia Adapter. dialect disambiguates ambiguous
+// numeric dates encountered while parsing episode metadata.
+func New(dialect dateparse.Dialect) *Adapter {
+	return &Adapter{dialect: dialect}
+}
+
+func (a *Adapter) AllowedDomains() []string {
+	return []string{"web.archive.org"}
+}
+
+func (a *Adapter) StartURL() string {
+	return "https://web.archive.org/web/20200704135748/http://www.pilkipedia.co.uk/wiki/index.php?title=Category:Transcripts"
+}
+
+func (a *Adapter) IndexSelector() string {
+	return `li > a`
+}
+
+func (a *Adapter) IsEpisodeLink(e *colly.HTMLElement) bool {
+	return strings.HasSuffix(e.Text, "/Transcript")
+}
+
+func (a *Adapter) ContentSelector() string {
+	return "div[id=content]"
+}
+
+// ParseMeta extracts date/publication/series metadata. It expects the
+// episode's title and first paragraph, e.g. "This is a transcription of
+// the 15 November 2003 episode, from Xfm Series 3".
+func (a *Adapter) ParseMeta(doc *colly.HTMLElement) ([]models.Metadata, error) {
+	var pageTitle *colly.HTMLElement
+	doc.ForEach("h1#firstHeading", func(i int, element *colly.HTMLElement) {
+		pageTitle = element
+	})
+
+	var pageDescription *colly.HTMLElement
+	doc.ForEach(".mw-parser-output > p:nth-child(1), #mw-content-text > p:nth-child(1)", func(i int, element *colly.HTMLElement) {
+		pageDescription = element
+	})
+
+	meta := []models.Metadata{}
+
+	if pageTitle == nil && pageDescription == nil {
+		return meta, nil
+	}
+
+	date, publication := getRawMetaParts(pageDescription)
+	if date == "" && pageTitle != nil {
+		// fall back to title
+		date = strings.TrimSpace(strings.TrimSuffix(pageTitle.Text, "/Transcript"))
+	}
+	if date == "" && publication == "" {
+		return nil, fmt.Errorf("couldn't parse meta from line: %s", pageDescription.Text)
+	}
+
+	dateMeta := models.Metadata{
+		Type:  models.MetadataTypeDate,
+		Value: "",
+	}
+
+	// e.g. 15 November 2003, 15th November 2003, Nov 15 2003, 15-16 Nov 2003...
+	parsed, err := dateparse.ParseEpisodeDate(date, a.dialect)
+	if err == nil {
+		dateMeta.Value = parsed.Format(time.RFC3339)
+	} else {
+		fmt.Printf("Failed to parse date %q: %s", date, err.Error())
+	}
+
+	meta = append(meta, dateMeta)
+
+	// Xfm Series 3
+	publication, series := parsePublication(publication)
+	if publication != "" {
+		meta = append(meta, models.Metadata{
+			Type:  models.MetadataTypePublication,
+			Value: publication,
+		})
+	}
+	if series != "" {
+		meta = append(meta, models.Metadata{
+			Type:  models.MetadataTypeSeries,
+			Value: series,
+		})
+	}
+
+	return meta, nil
+}
+
+// should return [date, publication series N]
+func getRawMetaParts(e *colly.HTMLElement) (string, string) {
+	if e == nil {
+		return "", ""
+	}
+	// try with tags
+	texts := trimStrings(e.ChildTexts("a"))
+	if len(texts) == 2 {
+		return texts[0], texts[1]
+	}
+	// try with regex
+	texts = trimStrings(regexp.MustCompile(`([0-9]{2}.+\w.+[0-9]{4}).+from(.+)`).FindStringSubmatch(e.Text))
+	if len(texts) == 3 {
+		return texts[1], texts[2]
+	}
+	return "", ""
+}
+
+func trimStrings(ss []string) []string {
+	for k := range ss {
+		ss[k] = strings.TrimSpace(ss[k])
+	}
+	return ss
+}
+
+func parsePublication(line string) (string, string) {
+	parts := strings.Split(strings.ToLower(line), "series")
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// ParseTranscript walks the page's dialog blocks, one div[style] per line.
+func (a *Adapter) ParseTranscript(doc *colly.HTMLElement) ([]models.Dialog, error) {
+	transcript := []models.Dialog{}
+	doc.ForEach("#mw-content-text > div[style], .mw-parser-output > div[style]", func(i int, element *colly.HTMLElement) {
+		dialog := parseDialogLine(element)
+		transcript = append(transcript, *dialog)
+	})
+	return transcript, nil
+}
+
+func parseDialogLine(el *colly.HTMLElement) *models.Dialog {
+	content, contentPrefix := cleanContent(el)
+
+	dialog := &models.Dialog{
+		Actor:   strings.ToLower(strings.TrimSuffix(strings.TrimSpace(el.ChildText("span")), ":")),
+		Type:    models.DialogTypeUnkown,
+		Content: content,
+	}
+	if contentPrefix == "song" {
+		dialog.Type = models.DialogTypeSong
+	} else {
+		if dialog.Actor != "" {
+			dialog.Type = models.DialogTypeChat
+		}
+	}
+
+	return dialog
+}
+
+func cleanContent(el *colly.HTMLElement) (string, string) {
+	raw := strings.ReplaceAll(strings.TrimSpace(el.Text), "\n", "")
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) == 2 {
+		return strings.TrimSpace(parts[1]), strings.TrimSpace(strings.ToLower(parts[0]))
+	}
+	return raw, ""
+}